@@ -0,0 +1,42 @@
+package auth
+
+import "context"
+
+type contextKey int
+
+const claimsContextKey contextKey = iota
+
+// Claims holds the parsed JWT claims for the current request, cached in
+// the request context after the auth middleware runs.
+type Claims struct {
+	Subject string   `json:"sub"`
+	Groups  []string `json:"groups"`
+}
+
+// HasGroup reports whether the claims include membership in at least one
+// of the given groups. A nil receiver (no claims parsed) never matches.
+func (c *Claims) HasGroup(groups ...string) bool {
+	if c == nil {
+		return false
+	}
+	for _, want := range groups {
+		for _, have := range c.Groups {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithClaims returns a copy of ctx carrying the parsed claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// ClaimsFromContext returns the claims cached on ctx by the auth
+// middleware, or nil if none were found.
+func ClaimsFromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsContextKey).(*Claims)
+	return claims
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthenticateGin is the Gin equivalent of Authenticate: it extracts the
+// bearer token, parses it with verify, and caches the resulting claims
+// on the request context so ACMustGin/ACHas see them. Register it ahead
+// of any ACMustGin/ACAdminGin/ACUserGin middleware - those only check
+// claims that are already in the context, they never parse a token.
+func AuthenticateGin(verify func(token string) (*Claims, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		claims, err := verify(token)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Request = c.Request.WithContext(WithClaims(c.Request.Context(), claims))
+		c.Next()
+	}
+}
+
+// ACMustGin is the Gin equivalent of ACMust, for routers that don't run
+// on the stdlib http.Handler chain.
+func ACMustGin(groups ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !ACHas(c.Request.Context(), groups...) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// ACAdminGin is shorthand for ACMustGin(ADMIN).
+func ACAdminGin() gin.HandlerFunc {
+	return ACMustGin(ADMIN)
+}
+
+// ACUserGin is shorthand for ACMustGin(USER, ADMIN).
+func ACUserGin() gin.HandlerFunc {
+	return ACMustGin(USER, ADMIN)
+}
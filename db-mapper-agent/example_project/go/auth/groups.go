@@ -0,0 +1,20 @@
+package auth
+
+import "strings"
+
+// systemPrefix marks a group name as reserved for internal use so that
+// user-defined groups (granted through models.GrantGroup) can never
+// collide with one of the constants below.
+const systemPrefix = "*"
+
+// Reserved group names understood by the RBAC middleware.
+const (
+	SYSTEM = systemPrefix + "system"
+	ADMIN  = systemPrefix + "admin"
+	USER   = systemPrefix + "user"
+)
+
+// IsSystemGroup reports whether name uses the reserved system prefix.
+func IsSystemGroup(name string) bool {
+	return strings.HasPrefix(name, systemPrefix)
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Authenticate returns middleware that extracts the bearer token from
+// the Authorization header, parses it with verify, and caches the
+// resulting claims in the request context. Downstream handlers reach
+// the claims through ACHas/ACMust without ever re-parsing the token.
+func Authenticate(verify func(token string) (*Claims, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			claims, err := verify(token)
+			if err != nil {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// ACHas reports whether the claims cached on ctx belong to at least one
+// of the given groups.
+func ACHas(ctx context.Context, groups ...string) bool {
+	return ClaimsFromContext(ctx).HasGroup(groups...)
+}
+
+// ACMust returns middleware that rejects the request with 403 Forbidden
+// unless the caller's cached claims belong to at least one of groups.
+func ACMust(groups ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !ACHas(r.Context(), groups...) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ACAdmin is shorthand for ACMust(ADMIN).
+func ACAdmin() func(http.Handler) http.Handler {
+	return ACMust(ADMIN)
+}
+
+// ACUser is shorthand for ACMust(USER, ADMIN).
+func ACUser() func(http.Handler) http.Handler {
+	return ACMust(USER, ADMIN)
+}
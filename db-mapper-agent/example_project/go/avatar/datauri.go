@@ -0,0 +1,49 @@
+package avatar
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+var allowedContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// decodedImage is a validated source image ready for encoding.
+type decodedImage struct {
+	ContentType string
+	Data        []byte
+}
+
+// parseDataURI decodes an RFC 2397 data URI of the form
+// "data:<content-type>;base64,<payload>".
+func parseDataURI(uri string) (*decodedImage, error) {
+	if !strings.HasPrefix(uri, "data:") {
+		return nil, ErrInvalidDataURI
+	}
+	rest := uri[len("data:"):]
+
+	semi := strings.Index(rest, ";")
+	comma := strings.Index(rest, ",")
+	if semi == -1 || comma == -1 || comma < semi {
+		return nil, ErrInvalidDataURI
+	}
+
+	contentType := rest[:semi]
+	if !allowedContentTypes[contentType] {
+		return nil, ErrInvalidContentType
+	}
+
+	if encoding := rest[semi+1 : comma]; encoding != "base64" {
+		return nil, ErrInvalidDataURI
+	}
+
+	data, err := base64.StdEncoding.DecodeString(rest[comma+1:])
+	if err != nil {
+		return nil, err
+	}
+	return &decodedImage{ContentType: contentType, Data: data}, nil
+}
@@ -0,0 +1,10 @@
+package avatar
+
+// Encoder re-encodes a decoded source image into a specific output
+// format at the given quality (0-100). Tests can swap in a fake Encoder so
+// the pipeline can be exercised without a real WebP/JPEG codec.
+type Encoder interface {
+	Encode(src *decodedImage, quality int) ([]byte, error)
+	ContentType() string
+	Extension() string
+}
@@ -0,0 +1,13 @@
+package avatar
+
+import "errors"
+
+var (
+	// ErrInvalidDataURI is returned when a data: URI is missing one of
+	// the "data:", ";", or "," separators.
+	ErrInvalidDataURI = errors.New("avatar: invalid data URI")
+
+	// ErrInvalidContentType is returned when the declared content type
+	// isn't one of image/png, image/jpeg, image/gif, or image/webp.
+	ErrInvalidContentType = errors.New("avatar: unsupported content type")
+)
@@ -0,0 +1,54 @@
+package avatar
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/ronxldwilson/disruptiq-notes/models"
+	"github.com/ronxldwilson/disruptiq-notes/storage"
+)
+
+// Handler serves GET /users/:id/avatar, content-negotiating on the
+// Accept header to prefer the smaller WebP variant when supported.
+type Handler struct {
+	DB    *gorm.DB
+	Store storage.Store
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/users/"), "/avatar")
+	userID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid user id", http.StatusBadRequest)
+		return
+	}
+
+	var profile models.Profile
+	if err := h.DB.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	key, contentType := profile.AvatarJPEG, "image/jpeg"
+	if profile.AvatarWebP != "" && strings.Contains(r.Header.Get("Accept"), "image/webp") {
+		key, contentType = profile.AvatarWebP, "image/webp"
+	}
+	if key == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	rc, err := h.Store.Get(r.Context(), bucket, key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	io.Copy(w, rc)
+}
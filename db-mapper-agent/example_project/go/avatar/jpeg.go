@@ -0,0 +1,29 @@
+package avatar
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+
+	_ "github.com/chai2010/webp" // decode support for webp sources
+)
+
+// jpegEncoder re-encodes any supported source image into JPEG.
+type jpegEncoder struct{}
+
+func (jpegEncoder) ContentType() string { return "image/jpeg" }
+func (jpegEncoder) Extension() string   { return ".jpg" }
+
+func (jpegEncoder) Encode(src *decodedImage, quality int) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(src.Data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+
+	"github.com/rs/xid"
+
+	"github.com/ronxldwilson/disruptiq-notes/models"
+	"github.com/ronxldwilson/disruptiq-notes/storage"
+)
+
+const (
+	webpQuality = 50
+	jpegQuality = 50
+
+	// bucket is the storage bucket avatar variants are written to.
+	bucket = "avatars"
+)
+
+// Pipeline validates a source image and re-encodes it into the WebP and
+// JPEG variants served as a user's avatar.
+type Pipeline struct {
+	Store       storage.Store
+	WebPEncoder Encoder
+	JPEGEncoder Encoder
+}
+
+// NewPipeline builds a Pipeline backed by store, using the default
+// WebP and JPEG encoders.
+func NewPipeline(store storage.Store) *Pipeline {
+	return &Pipeline{
+		Store:       store,
+		WebPEncoder: webpEncoder{},
+		JPEGEncoder: jpegEncoder{},
+	}
+}
+
+// FromDataURI decodes, validates, and re-encodes the image carried in an
+// RFC 2397 data URI, writing both variants onto profile.
+func (p *Pipeline) FromDataURI(ctx context.Context, profile *models.Profile, uri string) error {
+	src, err := parseDataURI(uri)
+	if err != nil {
+		return err
+	}
+	return p.apply(ctx, profile, src)
+}
+
+// FromMultipart decodes, validates, and re-encodes an uploaded image
+// file, writing both variants onto profile.
+func (p *Pipeline) FromMultipart(ctx context.Context, profile *models.Profile, file multipart.File, header *multipart.FileHeader) error {
+	declared := header.Header.Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(declared); err == nil {
+		declared = mt
+	}
+	if !allowedContentTypes[declared] {
+		return ErrInvalidContentType
+	}
+
+	data := make([]byte, header.Size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return err
+	}
+	return p.apply(ctx, profile, &decodedImage{ContentType: declared, Data: data})
+}
+
+func (p *Pipeline) apply(ctx context.Context, profile *models.Profile, src *decodedImage) error {
+	id := xid.New().String()
+
+	webpBytes, err := p.WebPEncoder.Encode(src, webpQuality)
+	if err != nil {
+		return fmt.Errorf("avatar: encode webp: %w", err)
+	}
+	jpegBytes, err := p.JPEGEncoder.Encode(src, jpegQuality)
+	if err != nil {
+		return fmt.Errorf("avatar: encode jpeg: %w", err)
+	}
+
+	webpKey := id + p.WebPEncoder.Extension()
+	if _, err := p.Store.Put(ctx, bucket, webpKey, bytes.NewReader(webpBytes), p.WebPEncoder.ContentType()); err != nil {
+		return fmt.Errorf("avatar: store webp: %w", err)
+	}
+	jpegKey := id + p.JPEGEncoder.Extension()
+	if _, err := p.Store.Put(ctx, bucket, jpegKey, bytes.NewReader(jpegBytes), p.JPEGEncoder.ContentType()); err != nil {
+		return fmt.Errorf("avatar: store jpeg: %w", err)
+	}
+
+	profile.AvatarWebP = webpKey
+	profile.AvatarJPEG = jpegKey
+	return nil
+}
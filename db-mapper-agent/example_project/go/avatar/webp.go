@@ -0,0 +1,26 @@
+package avatar
+
+import (
+	"bytes"
+	"image"
+
+	"github.com/chai2010/webp"
+)
+
+// webpEncoder re-encodes any supported source image into WebP.
+type webpEncoder struct{}
+
+func (webpEncoder) ContentType() string { return "image/webp" }
+func (webpEncoder) Extension() string   { return ".webp" }
+
+func (webpEncoder) Encode(src *decodedImage, quality int) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(src.Data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, &webp.Options{Quality: float32(quality)}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
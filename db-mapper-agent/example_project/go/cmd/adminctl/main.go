@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "adminctl",
+		Usage: "out-of-band administration for disruptiq-notes, bypassing the HTTP handlers",
+		Commands: []*cli.Command{
+			userCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func openDB() (*gorm.DB, error) {
+	return gorm.Open(mysql.Open(os.Getenv("DATABASE_DSN")), &gorm.Config{})
+}
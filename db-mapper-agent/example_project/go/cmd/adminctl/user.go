@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+
+	"github.com/ronxldwilson/disruptiq-notes/models"
+)
+
+func userCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "user",
+		Usage: "manage users",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create a user, prompting for a password",
+				ArgsUsage: "<username>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "email", Required: true},
+				},
+				Action: userCreate,
+			},
+			{
+				Name:      "delete",
+				Usage:     "soft-delete a user",
+				ArgsUsage: "<username>",
+				Action:    userDelete,
+			},
+			{
+				Name:      "reset-password",
+				Usage:     "reset a user's password, prompting twice",
+				ArgsUsage: "<username>",
+				Action:    userResetPassword,
+			},
+			{
+				Name:  "list",
+				Usage: "list users",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "active", Usage: "only list active users"},
+				},
+				Action: userList,
+			},
+			{
+				Name:      "grant",
+				Usage:     "grant a user membership in an RBAC group (rejects system groups like *admin)",
+				ArgsUsage: "<username> <group>",
+				Action:    userGrant,
+			},
+			{
+				Name:      "grant-system",
+				Usage:     "grant a user membership in a reserved system group, e.g. *admin",
+				ArgsUsage: "<username> <group>",
+				Action:    userGrantSystem,
+			},
+			{
+				Name:      "revoke",
+				Usage:     "revoke a user's membership in an RBAC group",
+				ArgsUsage: "<username> <group>",
+				Action:    userRevoke,
+			},
+		},
+	}
+}
+
+func userCreate(c *cli.Context) error {
+	username := c.Args().First()
+	if username == "" {
+		return fmt.Errorf("username is required")
+	}
+	email := c.String("email")
+
+	password, err := readPasswordTwice()
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).Where("username = ? OR email = ?", username, email).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("a user with that username or email already exists")
+	}
+
+	user := models.User{Username: username, Email: email, Password: string(hash)}
+	if err := db.Create(&user).Error; err != nil {
+		return err
+	}
+
+	fmt.Printf("created user %q (id=%d)\n", user.Username, user.ID)
+	return nil
+}
+
+func userDelete(c *cli.Context) error {
+	username := c.Args().First()
+	if username == "" {
+		return fmt.Errorf("username is required")
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("username = ?", username).Delete(&models.User{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no such user: %s", username)
+	}
+
+	fmt.Printf("deleted user %q\n", username)
+	return nil
+}
+
+func userResetPassword(c *cli.Context) error {
+	username := c.Args().First()
+	if username == "" {
+		return fmt.Errorf("username is required")
+	}
+
+	password, err := readPasswordTwice()
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	result := db.Model(&models.User{}).Where("username = ?", username).Update("password", string(hash))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no such user: %s", username)
+	}
+
+	fmt.Printf("reset password for %q\n", username)
+	return nil
+}
+
+func userList(c *cli.Context) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	var users []models.User
+	if c.Bool("active") {
+		users, err = models.GetActiveUsers(db)
+	} else {
+		err = db.Find(&users).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		fmt.Printf("%d\t%s\t%s\n", u.ID, u.Username, u.Email)
+	}
+	return nil
+}
+
+func userGrant(c *cli.Context) error {
+	username, group := c.Args().Get(0), c.Args().Get(1)
+	if username == "" || group == "" {
+		return fmt.Errorf("usage: adminctl user grant <username> <group>")
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	var user models.User
+	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
+		return fmt.Errorf("no such user: %s", username)
+	}
+
+	if err := models.GrantGroup(db, user.ID, group); err != nil {
+		return err
+	}
+
+	fmt.Printf("granted %q to %q\n", group, username)
+	return nil
+}
+
+func userGrantSystem(c *cli.Context) error {
+	username, group := c.Args().Get(0), c.Args().Get(1)
+	if username == "" || group == "" {
+		return fmt.Errorf("usage: adminctl user grant-system <username> <group>")
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	var user models.User
+	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
+		return fmt.Errorf("no such user: %s", username)
+	}
+
+	if err := models.GrantSystemGroup(db, user.ID, group); err != nil {
+		return err
+	}
+
+	fmt.Printf("granted system group %q to %q\n", group, username)
+	return nil
+}
+
+func userRevoke(c *cli.Context) error {
+	username, group := c.Args().Get(0), c.Args().Get(1)
+	if username == "" || group == "" {
+		return fmt.Errorf("usage: adminctl user revoke <username> <group>")
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	var user models.User
+	if err := db.Where("username = ?", username).First(&user).Error; err != nil {
+		return fmt.Errorf("no such user: %s", username)
+	}
+
+	if err := models.RevokeGroup(db, user.ID, group); err != nil {
+		return err
+	}
+
+	fmt.Printf("revoked %q from %q\n", group, username)
+	return nil
+}
+
+// readPasswordTwice prompts for a password twice, without echoing, and
+// requires both entries to match before returning.
+func readPasswordTwice() (string, error) {
+	fmt.Print("Password: ")
+	first, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Print("Confirm password: ")
+	second, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+
+	if string(first) != string(second) {
+		return "", fmt.Errorf("passwords do not match")
+	}
+	return string(first), nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/ronxldwilson/disruptiq-notes/seed"
+)
+
+func main() {
+	configPath := flag.String("config", "seed.json", "path to seed config file")
+	truncate := flag.Bool("truncate", false, "delete existing rows before seeding")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("seed: %v", err)
+	}
+	cfg.Truncate = cfg.Truncate || *truncate
+
+	db, err := gorm.Open(mysql.Open(os.Getenv("DATABASE_DSN")), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("seed: connect: %v", err)
+	}
+
+	if err := seed.Run(db, cfg); err != nil {
+		log.Fatalf("seed: %v", err)
+	}
+}
+
+func loadConfig(path string) (seed.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return seed.Config{}, err
+	}
+	var cfg seed.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return seed.Config{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Comment struct {
+	ID        uint           `gorm:"primarykey"`
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Content string `gorm:"type:text;not null"`
+
+	// Foreign keys
+	PostID uint `gorm:"not null"`
+	Post   Post `gorm:"foreignKey:PostID"`
+	UserID uint `gorm:"not null"`
+	User   User `gorm:"foreignKey:UserID"`
+}
+
+// TableName specifies the table name for Comment model
+func (Comment) TableName() string {
+	return "comments"
+}
+
+func GetCommentsForPost(db *gorm.DB, postID uint) ([]Comment, error) {
+	var comments []Comment
+	err := db.Where("post_id = ?", postID).Find(&comments).Error
+	return comments, err
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Profile struct {
+	ID        uint           `gorm:"primarykey"`
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	UserID uint   `gorm:"uniqueIndex;not null"`
+	Bio    string `gorm:"type:text"`
+
+	// AvatarWebP/AvatarJPEG hold the object keys of the two re-encoded
+	// variants produced by the avatar pipeline, not raw uploads.
+	AvatarWebP string `gorm:"column:avatar_webp"`
+	AvatarJPEG string `gorm:"column:avatar_jpeg"`
+}
+
+// TableName specifies the table name for Profile model
+func (Profile) TableName() string {
+	return "profiles"
+}
@@ -1,9 +1,12 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/ronxldwilson/disruptiq-notes/auth"
 )
 
 type User struct {
@@ -21,6 +24,11 @@ type User struct {
 	Posts    []Post    `gorm:"foreignKey:UserID"`
 	Profile  Profile   `gorm:"foreignKey:UserID"`
 	Comments []Comment `gorm:"foreignKey:UserID"`
+
+	// Groups backs the RBAC subsystem (see auth package). It is loaded
+	// on demand from the user_groups join table rather than preloaded
+	// automatically, since most queries don't need it.
+	Groups []string `gorm:"-"`
 }
 
 // TableName specifies the table name for User model
@@ -39,6 +47,51 @@ func (u *User) FullName() string {
 	return u.Username // In a real app, this might combine first/last name
 }
 
+// LoadGroups populates u.Groups from the user_groups join table.
+func (u *User) LoadGroups(db *gorm.DB) error {
+	var rows []UserGroup
+	if err := db.Where("user_id = ?", u.ID).Find(&rows).Error; err != nil {
+		return err
+	}
+	u.Groups = make([]string, len(rows))
+	for i, row := range rows {
+		u.Groups[i] = row.Group
+	}
+	return nil
+}
+
+// GrantGroup adds u to group, if it isn't already a member. Groups using
+// the reserved system prefix (auth.IsSystemGroup) are rejected here so
+// they can't collide with user-defined groups granted through this
+// path - use GrantSystemGroup from a privileged call site instead.
+func GrantGroup(db *gorm.DB, userID uint, group string) error {
+	if auth.IsSystemGroup(group) {
+		return fmt.Errorf("models: %q is a reserved system group, use GrantSystemGroup", group)
+	}
+	return grantGroup(db, userID, group)
+}
+
+// GrantSystemGroup grants u membership in a reserved system group (e.g.
+// auth.ADMIN). Callers are responsible for gating access to this
+// themselves - it exists only so privileged call sites can manage
+// system groups without opening GrantGroup up to arbitrary ones.
+func GrantSystemGroup(db *gorm.DB, userID uint, group string) error {
+	if !auth.IsSystemGroup(group) {
+		return fmt.Errorf("models: %q is not a system group", group)
+	}
+	return grantGroup(db, userID, group)
+}
+
+func grantGroup(db *gorm.DB, userID uint, group string) error {
+	return db.Where(UserGroup{UserID: userID, Group: group}).
+		FirstOrCreate(&UserGroup{UserID: userID, Group: group}).Error
+}
+
+// RevokeGroup removes u from group.
+func RevokeGroup(db *gorm.DB, userID uint, group string) error {
+	return db.Where("user_id = ? AND group_name = ?", userID, group).Delete(&UserGroup{}).Error
+}
+
 func GetActiveUsers(db *gorm.DB) ([]User, error) {
 	var users []User
 	err := db.Where("active = ?", true).Find(&users).Error
@@ -0,0 +1,14 @@
+package models
+
+// UserGroup is the join table backing User.Groups, associating a user
+// with an RBAC group by name. "group" is a reserved word in most SQL
+// dialects, so the column is named group_name.
+type UserGroup struct {
+	UserID uint   `gorm:"primarykey"`
+	Group  string `gorm:"column:group_name;primarykey"`
+}
+
+// TableName specifies the table name for UserGroup model
+func (UserGroup) TableName() string {
+	return "user_groups"
+}
@@ -0,0 +1,8 @@
+package runner
+
+// Config lists the binaries the runner is permitted to execute. Its JSON
+// form uses the allowed_binaries key; this package does not read any
+// config file itself, and the current callers build Config in code.
+type Config struct {
+	AllowedBinaries []string `json:"allowed_binaries"`
+}
@@ -0,0 +1,7 @@
+package runner
+
+import "errors"
+
+// ErrBinaryNotAllowed is returned when a Job names a binary that isn't
+// in the runner's configured allowlist.
+var ErrBinaryNotAllowed = errors.New("runner: binary is not in the allowlist")
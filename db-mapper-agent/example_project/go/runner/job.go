@@ -0,0 +1,17 @@
+package runner
+
+import (
+	"io"
+	"time"
+)
+
+// Job describes a single command invocation. Args are passed straight
+// to the resolved binary as argv - never concatenated into a shell
+// string - so there is no way for a caller to smuggle in extra commands.
+type Job struct {
+	Name    string
+	Args    []string
+	Stdin   io.Reader
+	Timeout time.Duration
+	Env     map[string]string
+}
@@ -0,0 +1,21 @@
+//go:build linux
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// applyProcessGroup puts the child in its own process group so
+// killProcessGroup can take down everything it spawned on timeout.
+func applyProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
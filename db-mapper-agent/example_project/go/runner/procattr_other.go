@@ -0,0 +1,13 @@
+//go:build !linux
+
+package runner
+
+import "os/exec"
+
+func applyProcessGroup(cmd *exec.Cmd) {}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/xid"
+)
+
+// AsyncResult is the outcome of a job enqueued through Queue.Enqueue,
+// keyed by the ID returned at enqueue time so clients can poll it.
+type AsyncResult struct {
+	Done   bool
+	Result *Result
+	Err    error
+}
+
+// Queue runs jobs asynchronously so long-running conversions don't
+// block the HTTP handler that triggered them.
+type Queue interface {
+	Enqueue(job Job) (id string, err error)
+	Poll(id string) (*AsyncResult, bool)
+}
+
+// MemoryQueue runs each job on its own goroutine and keeps its result in
+// memory. Polling does not remove a result, and results are never
+// evicted. It's meant for local dev; production deployments
+// should swap in an asynq-backed Queue behind the same interface.
+type MemoryQueue struct {
+	runner *Runner
+
+	mu      sync.Mutex
+	results map[string]*AsyncResult
+}
+
+func NewMemoryQueue(r *Runner) *MemoryQueue {
+	return &MemoryQueue{runner: r, results: make(map[string]*AsyncResult)}
+}
+
+func (q *MemoryQueue) Enqueue(job Job) (string, error) {
+	id := xid.New().String()
+
+	q.mu.Lock()
+	q.results[id] = &AsyncResult{}
+	q.mu.Unlock()
+
+	go func() {
+		result, err := q.runner.Run(context.Background(), job)
+		q.mu.Lock()
+		q.results[id] = &AsyncResult{Done: true, Result: result, Err: err}
+		q.mu.Unlock()
+	}()
+
+	return id, nil
+}
+
+func (q *MemoryQueue) Poll(id string) (*AsyncResult, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	result, ok := q.results[id]
+	return result, ok
+}
@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// maxOutputBytes caps how much stdout/stderr a single job may buffer,
+// so a runaway process can't exhaust memory.
+const maxOutputBytes = 10 << 20 // 10MB
+
+// defaultTimeout applies when a Job doesn't set one.
+const defaultTimeout = 30 * time.Second
+
+// Result holds a finished job's captured output.
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// Runner executes Jobs against an allowlist of binaries. It never
+// invokes a shell - job.Name is resolved directly via exec.Command and
+// job.Args are passed as a fixed argv.
+type Runner struct {
+	allowed map[string]bool
+}
+
+// New builds a Runner that only permits the binaries named in cfg.
+func New(cfg Config) *Runner {
+	allowed := make(map[string]bool, len(cfg.AllowedBinaries))
+	for _, bin := range cfg.AllowedBinaries {
+		allowed[bin] = true
+	}
+	return &Runner{allowed: allowed}
+}
+
+// Run executes job and blocks until it finishes, fails, or times out.
+func (r *Runner) Run(ctx context.Context, job Job) (*Result, error) {
+	if !r.allowed[job.Name] {
+		return nil, ErrBinaryNotAllowed
+	}
+
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, job.Name, job.Args...)
+	cmd.Stdin = job.Stdin
+	for k, v := range job.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	applyProcessGroup(cmd)
+
+	stdout := &capBuffer{limit: maxOutputBytes}
+	stderr := &capBuffer{limit: maxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// Wait blocks until the stdout/stderr pipes reach EOF, which needs
+	// every process in the group to exit - not just cmd's direct child.
+	// exec.CommandContext's own cancellation only kills that one process,
+	// so a forked helper that inherited the pipes could hang Wait forever.
+	// Race killProcessGroup against ctx directly instead of checking
+	// ctx.Err() after Wait returns, since by then it's too late to matter.
+	go func() {
+		<-ctx.Done()
+		killProcessGroup(cmd)
+	}()
+	runErr := cmd.Wait()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("runner: job %q timed out after %s", job.Name, timeout)
+	}
+
+	result := &Result{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	if exitErr, ok := runErr.(*exec.ExitError); ok {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if runErr != nil {
+		return nil, runErr
+	}
+	return result, nil
+}
+
+// capBuffer accumulates writes up to limit and silently discards the
+// rest, instead of growing without bound. It deliberately does NOT
+// embed bytes.Buffer: embedding would promote bytes.Buffer.ReadFrom,
+// and os/exec's io.Copy from the child's stdout/stderr pipe prefers
+// ReadFrom over Write whenever the destination implements
+// io.ReaderFrom - silently bypassing the cap entirely.
+type capBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+// Write reports that it consumed all of p, even past limit, so callers
+// like io.Copy don't treat the silent truncation as a short write.
+func (b *capBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if remaining := b.limit - b.buf.Len(); remaining < len(p) {
+		p = p[:max(remaining, 0)]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *capBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package seed
+
+// Generator names a fake-data generator a Config can select for a
+// given model field. This is deliberately a small, fixed set rather
+// than a full scripting language - it's enough indirection that which
+// fake method backs a column lives in the config instead of being
+// hardcoded in seed.go.
+type Generator string
+
+const (
+	// GenTitle produces a short, title-length sentence.
+	GenTitle Generator = "title"
+	// GenBody produces a long, body-length sentence.
+	GenBody Generator = "body"
+	// GenComment produces a comment-length sentence.
+	GenComment Generator = "comment"
+	// GenBio produces a bio-length sentence.
+	GenBio Generator = "bio"
+	// GenImageURL produces a placeholder image URL.
+	GenImageURL Generator = "image_url"
+	// GenNone produces an empty string, e.g. to seed profiles with no
+	// avatar at all.
+	GenNone Generator = "none"
+)
+
+// ModelConfig sets how many rows of a model to create, and optionally
+// overrides which Generator backs each of its seedable fields. A field
+// left out of Fields falls back to the default Generator that the
+// seeding code passes for that field (see ModelConfig.generator).
+type ModelConfig struct {
+	Count  int                  `json:"count"`
+	Fields map[string]Generator `json:"fields"`
+}
+
+// generator returns the Generator configured for field, or def if the
+// config doesn't override it.
+func (m ModelConfig) generator(field string, def Generator) Generator {
+	if g, ok := m.Fields[field]; ok {
+		return g
+	}
+	return def
+}
+
+// Config declares how many rows of each model the seeder should
+// create, and which generators back their fake-data fields.
+type Config struct {
+	Users    ModelConfig `json:"users"`
+	Posts    ModelConfig `json:"posts"`
+	Comments ModelConfig `json:"comments"`
+
+	// Truncate deletes existing rows in dependency order before
+	// seeding, so repeated runs stay idempotent.
+	Truncate bool `json:"truncate"`
+}
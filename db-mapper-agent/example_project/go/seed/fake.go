@@ -0,0 +1,72 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var firstNames = []string{"Ava", "Liam", "Noah", "Emma", "Olivia", "Mia", "Lucas", "Ethan"}
+var loremWords = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit"}
+
+// fake generates placeholder content for seeded rows. It isn't
+// cryptographically random - seeding is for local dev and load testing,
+// not anything security sensitive.
+type fake struct {
+	rand *rand.Rand
+}
+
+func newFake(seed int64) *fake {
+	return &fake{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (f *fake) username() string {
+	return fmt.Sprintf("%s%d", strings.ToLower(f.pick(firstNames)), f.rand.Intn(10000))
+}
+
+func (f *fake) email(username string) string {
+	return username + "@example.test"
+}
+
+func (f *fake) sentence(words int) string {
+	out := make([]string, words)
+	for i := range out {
+		out[i] = f.pick(loremWords)
+	}
+	return strings.Join(out, " ")
+}
+
+func (f *fake) imageURL() string {
+	return fmt.Sprintf("https://picsum.photos/seed/%d/200/200", f.rand.Intn(100000))
+}
+
+func (f *fake) pastTimestamp(within time.Duration) time.Time {
+	return time.Now().Add(-time.Duration(f.rand.Int63n(int64(within))))
+}
+
+func (f *fake) pick(options []string) string {
+	return options[f.rand.Intn(len(options))]
+}
+
+// generate dispatches to the fake method backing gen. Callers pass the
+// Generator a ModelConfig resolved for a given field, so which method
+// backs which column is driven by Config rather than hardcoded here.
+func (f *fake) generate(gen Generator) string {
+	switch gen {
+	case GenTitle:
+		return f.sentence(6)
+	case GenBody:
+		return f.sentence(40)
+	case GenComment:
+		return f.sentence(12)
+	case GenBio:
+		return f.sentence(15)
+	case GenImageURL:
+		return f.imageURL()
+	case GenNone:
+		return ""
+	default:
+		return f.sentence(12)
+	}
+}
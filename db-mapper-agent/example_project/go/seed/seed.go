@@ -0,0 +1,186 @@
+package seed
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ronxldwilson/disruptiq-notes/models"
+)
+
+const batchSize = 100
+
+// seedHistory is how far back CreatedAt/UpdatedAt are spread for seeded
+// rows, so a fresh seed doesn't leave every row stamped with the exact
+// moment the seed command happened to run.
+const seedHistory = 180 * 24 * time.Hour
+
+// Run seeds the schema according to cfg. When cfg.Truncate is set,
+// existing rows are deleted in dependency order first so repeated runs
+// stay idempotent; otherwise each model is only seeded if its table is
+// currently empty.
+func Run(db *gorm.DB, cfg Config) error {
+	if cfg.Truncate {
+		if err := truncate(db); err != nil {
+			return fmt.Errorf("seed: truncate: %w", err)
+		}
+	}
+
+	f := newFake(1)
+
+	users, err := seedUsers(db, f, cfg.Users)
+	if err != nil {
+		return fmt.Errorf("seed: users: %w", err)
+	}
+
+	if err := seedProfiles(db, f, cfg.Users, users); err != nil {
+		return fmt.Errorf("seed: profiles: %w", err)
+	}
+
+	posts, err := seedPosts(db, f, cfg.Posts, users)
+	if err != nil {
+		return fmt.Errorf("seed: posts: %w", err)
+	}
+
+	if err := seedComments(db, f, cfg.Comments, users, posts); err != nil {
+		return fmt.Errorf("seed: comments: %w", err)
+	}
+
+	return nil
+}
+
+// truncate deletes existing rows in reverse dependency order.
+func truncate(db *gorm.DB) error {
+	for _, table := range []string{"comments", "posts", "user_groups", "profiles", "users"} {
+		if err := db.Exec("DELETE FROM " + table).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedUsers only touches a table that's still empty - count first, seed
+// only if nothing is there; otherwise the existing users are returned.
+func seedUsers(db *gorm.DB, f *fake, cfg ModelConfig) ([]models.User, error) {
+	var existing int64
+	if err := db.Model(&models.User{}).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		var users []models.User
+		err := db.Find(&users).Error
+		return users, err
+	}
+
+	users := make([]models.User, cfg.Count)
+	for i := range users {
+		username := f.username()
+		createdAt := f.pastTimestamp(seedHistory)
+		users[i] = models.User{
+			Username:  username,
+			Email:     f.email(username),
+			Password:  "seeded",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&users, batchSize).Error
+	})
+	return users, err
+}
+
+// seedProfiles gives every seeded user a Profile row, using the bio/
+// avatar Generators configured on cfg (the same ModelConfig used for
+// the users themselves, since profiles are seeded 1:1 with users).
+func seedProfiles(db *gorm.DB, f *fake, cfg ModelConfig, users []models.User) error {
+	var existing int64
+	if err := db.Model(&models.Profile{}).Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 || len(users) == 0 {
+		return nil
+	}
+
+	bioGen := cfg.generator("bio", GenBio)
+	avatarGen := cfg.generator("avatar", GenImageURL)
+
+	profiles := make([]models.Profile, len(users))
+	for i, u := range users {
+		profiles[i] = models.Profile{
+			UserID:     u.ID,
+			Bio:        f.generate(bioGen),
+			AvatarJPEG: f.generate(avatarGen),
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&profiles, batchSize).Error
+	})
+}
+
+func seedPosts(db *gorm.DB, f *fake, cfg ModelConfig, users []models.User) ([]models.Post, error) {
+	var existing int64
+	if err := db.Model(&models.Post{}).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		var posts []models.Post
+		err := db.Find(&posts).Error
+		return posts, err
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	titleGen := cfg.generator("title", GenTitle)
+	contentGen := cfg.generator("content", GenBody)
+
+	posts := make([]models.Post, cfg.Count)
+	for i := range posts {
+		createdAt := f.pastTimestamp(seedHistory)
+		posts[i] = models.Post{
+			Title:     f.generate(titleGen),
+			Content:   f.generate(contentGen),
+			Status:    models.Published,
+			UserID:    users[f.rand.Intn(len(users))].ID,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&posts, batchSize).Error
+	})
+	return posts, err
+}
+
+func seedComments(db *gorm.DB, f *fake, cfg ModelConfig, users []models.User, posts []models.Post) error {
+	var existing int64
+	if err := db.Model(&models.Comment{}).Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 || len(users) == 0 || len(posts) == 0 {
+		return nil
+	}
+
+	contentGen := cfg.generator("content", GenComment)
+
+	comments := make([]models.Comment, cfg.Count)
+	for i := range comments {
+		createdAt := f.pastTimestamp(seedHistory)
+		comments[i] = models.Comment{
+			Content:   f.generate(contentGen),
+			UserID:    users[f.rand.Intn(len(users))].ID,
+			PostID:    posts[f.rand.Intn(len(posts))].ID,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&comments, batchSize).Error
+	})
+}
@@ -0,0 +1,20 @@
+package storage
+
+// Config configures the blob store backend, loaded at startup alongside
+// the rest of the app's configuration.
+type Config struct {
+	Endpoint        string `json:"endpoint"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	Bucket          string `json:"bucket"`
+	UseTLS          bool   `json:"use_tls"`
+}
+
+// New builds the Store described by cfg. An empty Endpoint selects the
+// local-filesystem backend rooted at localRoot, which is what tests use.
+func New(cfg Config, localRoot string) (Store, error) {
+	if cfg.Endpoint == "" {
+		return NewLocalStore(localRoot), nil
+	}
+	return NewMinioStore(cfg)
+}
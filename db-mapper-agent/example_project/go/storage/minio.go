@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MinioStore implements Store on top of a MinIO/S3-compatible endpoint.
+type MinioStore struct {
+	client *minio.Client
+}
+
+// NewMinioStore dials the endpoint described by cfg.
+func NewMinioStore(cfg Config) (*MinioStore, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseTLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MinioStore{client: client}, nil
+}
+
+func (s *MinioStore) Put(ctx context.Context, bucket, key string, r io.Reader, contentType string) (string, error) {
+	_, err := s.client.PutObject(ctx, bucket, key, r, -1, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return "", err
+	}
+	return s.client.EndpointURL().String() + "/" + bucket + "/" + key, nil
+}
+
+func (s *MinioStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return s.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+}
+
+func (s *MinioStore) Delete(ctx context.Context, bucket, key string) error {
+	return s.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *MinioStore) PresignedGetURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
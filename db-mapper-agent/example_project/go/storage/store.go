@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Store abstracts a blob store so callers don't need to know whether
+// objects live in MinIO/S3 or on local disk.
+type Store interface {
+	Put(ctx context.Context, bucket, key string, r io.Reader, contentType string) (url string, err error)
+	Get(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, bucket, key string) error
+	PresignedGetURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error)
+}
@@ -2,9 +2,12 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ronxldwilson/disruptiq-notes/auth"
 )
 
 func main() {
@@ -16,13 +19,15 @@ func main() {
 		})
 	})
 
-	router.POST("/users", func(c *gin.Context) {
+	authorized := router.Group("/", auth.AuthenticateGin(verifyToken))
+
+	authorized.POST("/users", auth.ACAdminGin(), func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "user created",
 		})
 	})
 
-	router.GET("/users/:id", func(c *gin.Context) {
+	authorized.GET("/users/:id", auth.ACUserGin(), func(c *gin.Context) {
 		id := c.Param("id")
 		c.JSON(http.StatusOK, gin.H{
 			"message": "user " + id,
@@ -31,3 +36,20 @@ func main() {
 
 	router.Run(":8080")
 }
+
+// verifyToken parses and validates a bearer token into auth.Claims.
+// TODO: replace with the real token issuer once it lands. Until then,
+// an "admin:"-prefixed token also grants ADMIN, so the ACAdminGin-gated
+// POST /users route above has a way to be exercised by this stub
+// instead of 403ing on every request.
+func verifyToken(token string) (*auth.Claims, error) {
+	if token == "" {
+		return nil, fmt.Errorf("missing bearer token")
+	}
+	groups := []string{auth.USER}
+	if rest, ok := strings.CutPrefix(token, "admin:"); ok {
+		token = rest
+		groups = append(groups, auth.ADMIN)
+	}
+	return &auth.Claims{Subject: token, Groups: groups}, nil
+}
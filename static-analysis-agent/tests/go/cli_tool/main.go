@@ -1,17 +1,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"math/rand"
-	"os"
-	"os/exec"
-	"path/filepath"
 	"strconv"
 	"time"
+
+	"github.com/ronxldwilson/disruptiq-notes/runner"
 )
 
 // Global variable - issue
@@ -30,7 +30,7 @@ func main() {
 	// Command line flags with issues
 	inputFile := flag.String("input", "", "Input file")
 	outputFile := flag.String("output", "output.txt", "Output file")
-	command := flag.String("exec", "", "Command to execute")
+	command := flag.String("exec", "", "Allowlisted binary to execute; extra positional args after -- are passed through as argv")
 	random := flag.Bool("random", false, "Generate random data")
 
 	flag.Parse()
@@ -48,7 +48,7 @@ func main() {
 	}
 
 	if *command != "" {
-		executeCommand(*command)
+		executeCommand(*command, flag.Args())
 	}
 }
 
@@ -68,13 +68,17 @@ func writeOutput(filename, content string) {
 	ioutil.WriteFile(filename, []byte(content), 0644)
 }
 
-func executeCommand(cmd string) {
-	// Security issue: command injection
-	out, err := exec.Command("sh", "-c", cmd).CombinedOutput()
+// executeCommand runs bin with args through the runner package's
+// allowlist instead of concatenating them into a shell string.
+func executeCommand(bin string, args []string) {
+	r := runner.New(runner.Config{AllowedBinaries: []string{"/usr/bin/convert", "/usr/bin/ffprobe"}})
+
+	result, err := r.Run(context.Background(), runner.Job{Name: bin, Args: args})
 	if err != nil {
 		log.Printf("Command error: %v", err)
+		return
 	}
-	fmt.Printf("Command output: %s\n", string(out))
+	fmt.Printf("Command output: %s\n", result.Stdout)
 }
 
 func generateRandomData() {